@@ -0,0 +1,129 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// GrafanaDashboardSpec defines the desired state of GrafanaDashboard
+// +k8s:openapi-gen=true
+type GrafanaDashboardSpec struct {
+	// Name overrides the dashboard title used inside Grafana. Defaults to
+	// the CR name.
+	Name string `json:"name,omitempty"`
+	// CustomFolderName is not read by the dashboard controller; the folder
+	// is chosen from Folder, a matching GrafanaFolder or the namespace
+	// default.
+	CustomFolderName string `json:"customFolderName,omitempty"`
+	// Json is the dashboard content, inlined as a JSON string.
+	Json string `json:"json,omitempty"`
+	// Url fetches the dashboard content from a remote HTTP(S) endpoint.
+	Url string `json:"url,omitempty"`
+	// UrlAuthSecretRef points at a Secret holding credentials for Url: either
+	// "username"/"password" for HTTP basic auth, or "token" for a bearer
+	// token. Optional.
+	UrlAuthSecretRef *ConfigMapKeySelector `json:"urlAuthSecretRef,omitempty"`
+	// ConfigMapRef fetches the dashboard content from a key in a ConfigMap,
+	// which may live in any namespace allowed by the namespace selector.
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty"`
+	// Jsonnet is a Jsonnet/Grafonnet program evaluated in-process to produce
+	// the dashboard JSON.
+	Jsonnet string `json:"jsonnet,omitempty"`
+	// Folder names a GrafanaFolder, by its name or its status UID, to file
+	// this dashboard under. Takes priority over a GrafanaFolder matched by
+	// namespace selector and the namespace default when set. The referenced
+	// GrafanaFolder's NamespaceSelector must select the dashboard's
+	// namespace; otherwise the dashboard is not applied.
+	Folder  string     `json:"folder,omitempty"`
+	Plugins PluginList `json:"plugins,omitempty"`
+}
+
+// ConfigMapKeySelector selects a key from a ConfigMap or Secret, optionally
+// in a namespace other than the owning CR's.
+// +k8s:openapi-gen=true
+type ConfigMapKeySelector struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Key       string `json:"key"`
+}
+
+// GrafanaDashboardStatus defines the observed state of GrafanaDashboard
+// +k8s:openapi-gen=true
+type GrafanaDashboardStatus struct {
+	Hash string `json:"hash"`
+	UID  string `json:"UID"`
+	// LastAppliedHash is the hash of the content that was last pushed to
+	// Grafana, persisted so a restart doesn't lose track of no-op state.
+	LastAppliedHash string `json:"lastAppliedHash,omitempty"`
+	// ContentCacheEtag is the ETag returned by the remote server the last
+	// time a Url-sourced dashboard was fetched.
+	ContentCacheEtag string `json:"contentCacheEtag,omitempty"`
+	// ContentCache is the dashboard body fetched the last time ContentCacheEtag
+	// was refreshed, returned as-is when the server replies 304 Not Modified.
+	ContentCache string `json:"contentCache,omitempty"`
+	// ContentCacheTimestamp is when ContentCacheEtag was last refreshed.
+	ContentCacheTimestamp string `json:"contentCacheTimestamp,omitempty"`
+	// OperatorFolderUID is set when the dashboard was filed into the
+	// namespace-default folder the operator created on its behalf (as
+	// opposed to a folder referenced via spec.folder or a GrafanaFolder CR),
+	// so that folder can be garbage collected once it is empty.
+	OperatorFolderUID string `json:"operatorFolderUID,omitempty"`
+}
+
+// GrafanaDashboard is the Schema for the grafanadashboards API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type GrafanaDashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrafanaDashboardSpec   `json:"spec,omitempty"`
+	Status GrafanaDashboardStatus `json:"status,omitempty"`
+}
+
+// GrafanaDashboardList contains a list of GrafanaDashboard
+type GrafanaDashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrafanaDashboard `json:"items"`
+}
+
+// GrafanaDashboardRef is the representation of a dashboard the operator
+// knows about, tracked by the controller config between reconciles.
+type GrafanaDashboardRef struct {
+	Name       string
+	Namespace  string
+	UID        string
+	Hash       string
+	FolderId   int64
+	FolderName string
+}
+
+// GrafanaPlugin describes a single Grafana plugin dependency.
+// +k8s:openapi-gen=true
+type GrafanaPlugin struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// PluginList is a list of plugin dependencies declared by a dashboard.
+type PluginList []GrafanaPlugin
+
+func init() {
+	SchemeBuilder.Register(&GrafanaDashboard{}, &GrafanaDashboardList{})
+}
+
+// MatchesSelectors checks if the current dashboard instance matches a given
+// array of label selectors.
+func (in *GrafanaDashboard) MatchesSelectors(selectors []*metav1.LabelSelector) (bool, error) {
+	for _, s := range selectors {
+		selector, err := metav1.LabelSelectorAsSelector(s)
+		if err != nil {
+			return false, err
+		}
+		if selector.Empty() || selector.Matches(labels.Set(in.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// GrafanaDataSourceSpec defines the desired state of GrafanaDataSource
+// +k8s:openapi-gen=true
+type GrafanaDataSourceSpec struct {
+	Name        string                    `json:"name"`
+	Datasources []GrafanaDataSourceFields `json:"datasources"`
+}
+
+// GrafanaDataSourceFields is the configuration for a single datasource, modeled
+// after Grafana's provisioning file format so that it can be templated
+// directly into a provisioning payload.
+// +k8s:openapi-gen=true
+type GrafanaDataSourceFields struct {
+	Name              string                          `json:"name"`
+	Type              string                          `json:"type"`
+	Access            string                          `json:"access"`
+	OrgId             int64                           `json:"orgId,omitempty"`
+	Url               string                          `json:"url"`
+	Password          string                          `json:"password,omitempty"`
+	User              string                          `json:"user,omitempty"`
+	Database          string                          `json:"database,omitempty"`
+	BasicAuth         bool                            `json:"basicAuth,omitempty"`
+	BasicAuthUser     string                          `json:"basicAuthUser,omitempty"`
+	BasicAuthPassword string                          `json:"basicAuthPassword,omitempty"`
+	WithCredentials   bool                            `json:"withCredentials,omitempty"`
+	IsDefault         bool                            `json:"isDefault,omitempty"`
+	JsonData          GrafanaDataSourceJsonData       `json:"jsonData,omitempty"`
+	SecureJsonData    GrafanaDataSourceSecureJsonData `json:"secureJsonData,omitempty"`
+	// SecureJsonDataFrom allows secureJsonData values to be sourced from a
+	// Secret or ConfigMap instead of being inlined in the CR.
+	SecureJsonDataFrom map[string]GrafanaDataSourceValueFrom `json:"secureJsonDataFrom,omitempty"`
+	Version            int                                   `json:"version,omitempty"`
+	Editable           bool                                  `json:"editable,omitempty"`
+}
+
+// GrafanaDataSourceValueFrom sources a single secureJsonData value from a
+// Secret or ConfigMap key, the same way a Pod sources an env var.
+// +k8s:openapi-gen=true
+type GrafanaDataSourceValueFrom struct {
+	SecretKeyRef    *GrafanaDataSourceSecretRef    `json:"secretKeyRef,omitempty"`
+	ConfigMapKeyRef *GrafanaDataSourceConfigMapRef `json:"configMapKeyRef,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type GrafanaDataSourceSecretRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// +k8s:openapi-gen=true
+type GrafanaDataSourceConfigMapRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// GrafanaDataSourceJsonData holds the subset of Grafana's jsonData block that
+// the operator understands. Fields map 1:1 to Grafana's provisioning schema.
+// +k8s:openapi-gen=true
+type GrafanaDataSourceJsonData struct {
+	OauthPassThru     bool   `json:"oauthPassThru,omitempty"`
+	TlsAuth           bool   `json:"tlsAuth,omitempty"`
+	TlsAuthWithCACert bool   `json:"tlsAuthWithCACert,omitempty"`
+	TlsSkipVerify     bool   `json:"tlsSkipVerify,omitempty"`
+	ServerName        string `json:"serverName,omitempty"`
+	TimeInterval      string `json:"timeInterval,omitempty"`
+	QueryTimeout      string `json:"queryTimeout,omitempty"`
+	HttpMode          string `json:"httpMode,omitempty"`
+	HttpMethod        string `json:"httpMethod,omitempty"`
+	HttpHeaderName1   string `json:"httpHeaderName1,omitempty"`
+	HttpHeaderValue1  string `json:"httpHeaderValue1,omitempty"`
+	SigV4Auth         bool   `json:"sigV4Auth,omitempty"`
+	SigV4AuthType     string `json:"sigV4AuthType,omitempty"`
+	SigV4Region       string `json:"sigV4Region,omitempty"`
+}
+
+// GrafanaDataSourceSecureJsonData holds credential material that is written
+// to Grafana's secureJsonData block and never persisted back to the CR.
+// +k8s:openapi-gen=true
+type GrafanaDataSourceSecureJsonData struct {
+	TlsCACert         string `json:"tlsCACert,omitempty"`
+	TlsClientCert     string `json:"tlsClientCert,omitempty"`
+	TlsClientKey      string `json:"tlsClientKey,omitempty"`
+	Password          string `json:"password,omitempty"`
+	BasicAuthPassword string `json:"basicAuthPassword,omitempty"`
+	AccessKey         string `json:"accessKey,omitempty"`
+	SecretKey         string `json:"secretKey,omitempty"`
+}
+
+// GrafanaDataSourceStatus defines the observed state of GrafanaDataSource
+// +k8s:openapi-gen=true
+type GrafanaDataSourceStatus struct {
+	Hash string `json:"hash"`
+	UID  string `json:"UID"`
+}
+
+// GrafanaDataSource is the Schema for the grafanadatasources API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type GrafanaDataSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrafanaDataSourceSpec   `json:"spec,omitempty"`
+	Status GrafanaDataSourceStatus `json:"status,omitempty"`
+}
+
+// GrafanaDataSourceList contains a list of GrafanaDataSource
+type GrafanaDataSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrafanaDataSource `json:"items"`
+}
+
+// GrafanaDataSourceRef is the representation of a datasource the operator
+// knows about, tracked by the controller config the same way dashboards are.
+type GrafanaDataSourceRef struct {
+	Name      string
+	Namespace string
+	UID       string
+	Hash      string
+}
+
+func init() {
+	SchemeBuilder.Register(&GrafanaDataSource{}, &GrafanaDataSourceList{})
+}
+
+// MatchesSelectors checks if the current data source instance matches a given
+// array of label selectors, mirroring GrafanaDashboard.MatchesSelectors.
+func (in *GrafanaDataSource) MatchesSelectors(selectors []*metav1.LabelSelector) (bool, error) {
+	for _, s := range selectors {
+		selector, err := metav1.LabelSelectorAsSelector(s)
+		if err != nil {
+			return false, err
+		}
+		if selector.Empty() || selector.Matches(labels.Set(in.Labels)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
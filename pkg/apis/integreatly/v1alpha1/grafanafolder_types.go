@@ -0,0 +1,63 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrafanaFolderSpec defines the desired state of GrafanaFolder
+// +k8s:openapi-gen=true
+type GrafanaFolderSpec struct {
+	// Title is the folder title in Grafana. Defaults to the CR name.
+	Title string `json:"title,omitempty"`
+	// NamespaceSelector selects which namespaces' dashboards default into
+	// this folder. A dashboard that references it directly via spec.folder
+	// is also only filed here if its namespace is selected. A nil selector
+	// selects no namespace.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Permissions are applied to the folder via Grafana's
+	// /api/folders/{uid}/permissions endpoint on every reconcile.
+	Permissions []GrafanaFolderPermission `json:"permissions,omitempty"`
+}
+
+// GrafanaFolderPermission grants a permission level to a team or role on the
+// folder, mirroring Grafana's folder permission item.
+// +k8s:openapi-gen=true
+type GrafanaFolderPermission struct {
+	// Team is the name of a Grafana team to grant permission to.
+	Team string `json:"team,omitempty"`
+	// Role is a Grafana org role (Viewer, Editor, Admin) to grant permission to.
+	Role string `json:"role,omitempty"`
+	// PermissionLevel is one of View, Edit, Admin.
+	PermissionLevel string `json:"permissionLevel"`
+}
+
+// GrafanaFolderStatus defines the observed state of GrafanaFolder
+// +k8s:openapi-gen=true
+type GrafanaFolderStatus struct {
+	UID string `json:"UID"`
+	ID  int64  `json:"ID"`
+}
+
+// GrafanaFolder is the Schema for the grafanafolders API. It is
+// cluster-scoped: a single GrafanaFolder can be the default folder for
+// dashboards across several namespaces via NamespaceSelector.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type GrafanaFolder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrafanaFolderSpec   `json:"spec,omitempty"`
+	Status GrafanaFolderStatus `json:"status,omitempty"`
+}
+
+// GrafanaFolderList contains a list of GrafanaFolder
+type GrafanaFolderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrafanaFolder `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrafanaFolder{}, &GrafanaFolderList{})
+}
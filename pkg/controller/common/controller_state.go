@@ -0,0 +1,24 @@
+package common
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ControllerState is shared, read-mostly state broadcast by the Grafana
+// controller to the dashboard and datasource controllers once a Grafana
+// instance becomes available.
+type ControllerState struct {
+	GrafanaReady                bool
+	AdminUrl                    string
+	AdminUsername               string
+	AdminPassword               string
+	ClientTimeout               int
+	DashboardSelectors          []*metav1.LabelSelector
+	DashboardNamespaceSelector  *metav1.LabelSelector
+	DataSourceSelectors         []*metav1.LabelSelector
+	DataSourceNamespaceSelector *metav1.LabelSelector
+}
+
+// ControllerEvents is used by the Grafana controller to notify the other
+// controllers that the ControllerState has changed.
+var ControllerEvents = make(chan ControllerState)
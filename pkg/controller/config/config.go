@@ -0,0 +1,192 @@
+package config
+
+import (
+	"sync"
+	"time"
+
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+)
+
+// RequeueDelay is the tick interval of the dashboard and datasource
+// controllers' background ticker. The dashboard controller no longer runs a
+// full resync on every tick (see FullResyncInterval); the datasource
+// controller still does.
+const RequeueDelay = time.Second * 5
+
+// FullResyncInterval is the maximum time between unconditional full
+// resyncs of all dashboards in a namespace. Between ticks that are this far
+// apart, a resync is only triggered by a ControllerState change.
+var FullResyncInterval = time.Minute * 10
+
+const (
+	// ConfigGrafanaDashboardsSynced signals that the dashboard controller has
+	// finished reconciling all known dashboards.
+	ConfigGrafanaDashboardsSynced = "grafana.dashboards.synced"
+	// ConfigGrafanaDataSourcesSynced signals that the datasource controller
+	// has finished reconciling all known datasources.
+	ConfigGrafanaDataSourcesSynced = "grafana.datasources.synced"
+)
+
+var instance *ControllerConfig
+var once sync.Once
+
+// ControllerConfig keeps track of the dashboards and datasources the
+// operator has successfully applied to Grafana, plus a generic key/value
+// store used to signal cross-controller state.
+type ControllerConfig struct {
+	mutex       sync.Mutex
+	dashboards  map[string][]*grafanav1alpha1.GrafanaDashboardRef
+	datasources map[string][]*grafanav1alpha1.GrafanaDataSourceRef
+	plugins     map[string]grafanav1alpha1.PluginList
+	values      map[string]interface{}
+}
+
+// GetControllerConfig returns the singleton ControllerConfig.
+func GetControllerConfig() *ControllerConfig {
+	once.Do(func() {
+		instance = &ControllerConfig{
+			dashboards:  map[string][]*grafanav1alpha1.GrafanaDashboardRef{},
+			datasources: map[string][]*grafanav1alpha1.GrafanaDataSourceRef{},
+			plugins:     map[string]grafanav1alpha1.PluginList{},
+			values:      map[string]interface{}{},
+		}
+	})
+	return instance
+}
+
+// AddConfigItem stores a value under a key, used by the controllers to
+// signal state back to the Grafana controller.
+func (c *ControllerConfig) AddConfigItem(key string, value interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values[key] = value
+}
+
+// GetConfigItem returns a previously stored value, or nil if not set.
+func (c *ControllerConfig) GetConfigItem(key string) interface{} {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.values[key]
+}
+
+// GetDashboards returns the dashboards known in a given namespace. An empty
+// namespace returns dashboards across all namespaces.
+func (c *ControllerConfig) GetDashboards(namespace string) []*grafanav1alpha1.GrafanaDashboardRef {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if namespace == "" {
+		var all []*grafanav1alpha1.GrafanaDashboardRef
+		for _, v := range c.dashboards {
+			all = append(all, v...)
+		}
+		return all
+	}
+	return c.dashboards[namespace]
+}
+
+// AddDashboard records that a dashboard was successfully applied to Grafana.
+func (c *ControllerConfig) AddDashboard(cr *grafanav1alpha1.GrafanaDashboard) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ref := &grafanav1alpha1.GrafanaDashboardRef{
+		Name:       cr.Name,
+		Namespace:  cr.Namespace,
+		UID:        cr.Status.UID,
+		Hash:       cr.Status.Hash,
+		FolderName: cr.Status.OperatorFolderUID,
+	}
+
+	list := c.dashboards[cr.Namespace]
+	for i, d := range list {
+		if d.Name == ref.Name {
+			list[i] = ref
+			c.dashboards[cr.Namespace] = list
+			return
+		}
+	}
+	c.dashboards[cr.Namespace] = append(list, ref)
+}
+
+// RemoveDashboard forgets a dashboard that no longer exists.
+func (c *ControllerConfig) RemoveDashboard(namespace, name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var remaining []*grafanav1alpha1.GrafanaDashboardRef
+	for _, d := range c.dashboards[namespace] {
+		if d.Name != name {
+			remaining = append(remaining, d)
+		}
+	}
+	c.dashboards[namespace] = remaining
+}
+
+// GetDatasources returns the datasources known in a given namespace. An empty
+// namespace returns datasources across all namespaces.
+func (c *ControllerConfig) GetDatasources(namespace string) []*grafanav1alpha1.GrafanaDataSourceRef {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if namespace == "" {
+		var all []*grafanav1alpha1.GrafanaDataSourceRef
+		for _, v := range c.datasources {
+			all = append(all, v...)
+		}
+		return all
+	}
+	return c.datasources[namespace]
+}
+
+// AddDatasource records that a datasource was successfully applied to Grafana.
+func (c *ControllerConfig) AddDatasource(cr *grafanav1alpha1.GrafanaDataSource) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ref := &grafanav1alpha1.GrafanaDataSourceRef{
+		Name:      cr.Name,
+		Namespace: cr.Namespace,
+		UID:       cr.Status.UID,
+		Hash:      cr.Status.Hash,
+	}
+
+	list := c.datasources[cr.Namespace]
+	for i, d := range list {
+		if d.Name == ref.Name {
+			list[i] = ref
+			c.datasources[cr.Namespace] = list
+			return
+		}
+	}
+	c.datasources[cr.Namespace] = append(list, ref)
+}
+
+// RemoveDatasource forgets a datasource that no longer exists.
+func (c *ControllerConfig) RemoveDatasource(namespace, name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var remaining []*grafanav1alpha1.GrafanaDataSourceRef
+	for _, d := range c.datasources[namespace] {
+		if d.Name != name {
+			remaining = append(remaining, d)
+		}
+	}
+	c.datasources[namespace] = remaining
+}
+
+// SetPluginsFor registers the plugins required by a dashboard so the Grafana
+// controller can reconcile the plugin list for the instance.
+func (c *ControllerConfig) SetPluginsFor(cr *grafanav1alpha1.GrafanaDashboard) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.plugins[cr.Namespace+"/"+cr.Name] = cr.Spec.Plugins
+}
+
+// RemovePluginsFor forgets the plugins registered for a dashboard.
+func (c *ControllerConfig) RemovePluginsFor(namespace, name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.plugins, namespace+"/"+name)
+}
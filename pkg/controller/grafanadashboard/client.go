@@ -0,0 +1,363 @@
+package grafanadashboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+)
+
+// GrafanaResponse is the body returned by Grafana's dashboard and datasource
+// admin API endpoints on create/update/delete.
+type GrafanaResponse struct {
+	ID      *int64  `json:"id,omitempty"`
+	UID     *string `json:"uid,omitempty"`
+	Status  *string `json:"status,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// GrafanaFolder represents a Grafana folder as returned by the folders API.
+type GrafanaFolder struct {
+	ID    *int64 `json:"id,omitempty"`
+	UID   string `json:"uid,omitempty"`
+	Title string `json:"title,omitempty"`
+}
+
+// GrafanaClient talks to a single Grafana instance's admin HTTP API. It is
+// shared by the dashboard and datasource controllers.
+type GrafanaClient interface {
+	CreateOrUpdateDashboard(dashboard []byte, folderId int64) (*GrafanaResponse, error)
+	DeleteDashboardByUID(uid string) (*GrafanaResponse, error)
+	GetDashboardByUID(uid string) (*GrafanaResponse, error)
+	GetOrCreateNamespaceFolder(namespace string) (*GrafanaFolder, error)
+	CreateOrUpdateDatasource(datasource []byte) (*GrafanaResponse, error)
+	DeleteDatasourceByUID(uid string) (*GrafanaResponse, error)
+	GetFolderByUIDOrName(uidOrName string) (*GrafanaFolder, error)
+	CreateOrUpdateFolder(uid, title string) (*GrafanaFolder, error)
+	SetFolderPermissions(uid string, permissions []grafanav1alpha1.GrafanaFolderPermission) error
+	DeleteFolder(uid string) error
+	CountDashboardsInFolder(uid string) (int, error)
+}
+
+type grafanaClientImpl struct {
+	url      string
+	user     string
+	password string
+	client   *http.Client
+}
+
+// NewGrafanaClient creates an authenticated GrafanaClient for the instance at
+// url, using the admin credentials resolved from the operator's state.
+func NewGrafanaClient(url, user, password string, timeout time.Duration) GrafanaClient {
+	return &grafanaClientImpl{
+		url:      url,
+		user:     user,
+		password: password,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *grafanaClientImpl) doRequest(method, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", c.url, path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.user, c.password)
+	req.Header.Set("Content-Type", "application/json")
+	return c.client.Do(req)
+}
+
+// checkStatus returns an error describing the response body if resp did not
+// come back with a 2xx status. Callers that need to keep reading the body
+// afterwards (e.g. to decode JSON) should only call this once, since it
+// drains resp.Body on failure.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	return fmt.Errorf("grafana api returned %s: %s", resp.Status, string(body))
+}
+
+func (c *grafanaClientImpl) CreateOrUpdateDashboard(dashboard []byte, folderId int64) (*GrafanaResponse, error) {
+	wrapped := map[string]interface{}{
+		"dashboard": json.RawMessage(dashboard),
+		"folderId":  folderId,
+		"overwrite": true,
+	}
+	payload, err := json.Marshal(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.doRequest(http.MethodPost, "/api/dashboards/db", payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var out GrafanaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *grafanaClientImpl) DeleteDashboardByUID(uid string) (*GrafanaResponse, error) {
+	resp, err := c.doRequest(http.MethodDelete, fmt.Sprintf("/api/dashboards/uid/%s", uid), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var out GrafanaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *grafanaClientImpl) GetDashboardByUID(uid string) (*GrafanaResponse, error) {
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("/api/dashboards/uid/%s", uid), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var out GrafanaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *grafanaClientImpl) GetOrCreateNamespaceFolder(namespace string) (*GrafanaFolder, error) {
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("/api/folders/%s", namespace), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var folder GrafanaFolder
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&folder); err != nil {
+			return nil, err
+		}
+		return &folder, nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return nil, checkStatus(resp)
+	}
+
+	payload, err := json.Marshal(map[string]string{"uid": namespace, "title": namespace})
+	if err != nil {
+		return nil, err
+	}
+	createResp, err := c.doRequest(http.MethodPost, "/api/folders", payload)
+	if err != nil {
+		return nil, err
+	}
+	defer createResp.Body.Close()
+	if err := checkStatus(createResp); err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(createResp.Body).Decode(&folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+func (c *grafanaClientImpl) CreateOrUpdateDatasource(datasource []byte) (*GrafanaResponse, error) {
+	resp, err := c.doRequest(http.MethodPost, "/api/datasources", datasource)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var out GrafanaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *grafanaClientImpl) DeleteDatasourceByUID(uid string) (*GrafanaResponse, error) {
+	resp, err := c.doRequest(http.MethodDelete, fmt.Sprintf("/api/datasources/uid/%s", uid), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	var out GrafanaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetFolderByUIDOrName looks up a folder either by UID (if uidOrName matches
+// an existing folder's uid) or, failing that, by searching for a folder with
+// that title. Returns nil, nil if no such folder exists.
+func (c *grafanaClientImpl) GetFolderByUIDOrName(uidOrName string) (*GrafanaFolder, error) {
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("/api/folders/%s", uidOrName), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var folder GrafanaFolder
+		if err := json.NewDecoder(resp.Body).Decode(&folder); err != nil {
+			return nil, err
+		}
+		return &folder, nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return nil, checkStatus(resp)
+	}
+
+	searchResp, err := c.doRequest(http.MethodGet, fmt.Sprintf("/api/search?type=dash-folder&query=%s", uidOrName), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer searchResp.Body.Close()
+	if err := checkStatus(searchResp); err != nil {
+		return nil, err
+	}
+
+	var results []GrafanaFolder
+	if err := json.NewDecoder(searchResp.Body).Decode(&results); err != nil {
+		return nil, err
+	}
+	for _, folder := range results {
+		if folder.Title == uidOrName {
+			return &folder, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateOrUpdateFolder returns the folder identified by uid, creating it
+// with the given title if it doesn't exist yet.
+func (c *grafanaClientImpl) CreateOrUpdateFolder(uid, title string) (*GrafanaFolder, error) {
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("/api/folders/%s", uid), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var folder GrafanaFolder
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&folder); err != nil {
+			return nil, err
+		}
+		return &folder, nil
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		return nil, checkStatus(resp)
+	}
+
+	payload, err := json.Marshal(map[string]string{"uid": uid, "title": title})
+	if err != nil {
+		return nil, err
+	}
+	createResp, err := c.doRequest(http.MethodPost, "/api/folders", payload)
+	if err != nil {
+		return nil, err
+	}
+	defer createResp.Body.Close()
+	if err := checkStatus(createResp); err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(createResp.Body).Decode(&folder); err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// SetFolderPermissions overwrites a folder's permissions with the given team
+// and role ACL entries.
+func (c *grafanaClientImpl) SetFolderPermissions(uid string, permissions []grafanav1alpha1.GrafanaFolderPermission) error {
+	items := make([]map[string]interface{}, 0, len(permissions))
+	for _, p := range permissions {
+		item := map[string]interface{}{"permission": permissionLevelToInt(p.PermissionLevel)}
+		if p.Team != "" {
+			item["team"] = p.Team
+		}
+		if p.Role != "" {
+			item["role"] = p.Role
+		}
+		items = append(items, item)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"items": items})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(http.MethodPost, fmt.Sprintf("/api/folders/%s/permissions", uid), payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+// permissionLevelToInt maps the CR's human-readable permission level to
+// Grafana's numeric permission enum (1=View, 2=Edit, 4=Admin).
+func permissionLevelToInt(level string) int {
+	switch level {
+	case "Edit":
+		return 2
+	case "Admin":
+		return 4
+	default:
+		return 1
+	}
+}
+
+// DeleteFolder removes a folder by UID.
+func (c *grafanaClientImpl) DeleteFolder(uid string) error {
+	resp, err := c.doRequest(http.MethodDelete, fmt.Sprintf("/api/folders/%s", uid), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return checkStatus(resp)
+}
+
+// CountDashboardsInFolder returns how many dashboards currently live in a
+// folder, used to decide whether an operator-created folder can be garbage
+// collected.
+func (c *grafanaClientImpl) CountDashboardsInFolder(uid string) (int, error) {
+	resp, err := c.doRequest(http.MethodGet, fmt.Sprintf("/api/search?type=dash-db&folderUIDs=%s", uid), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp); err != nil {
+		return 0, err
+	}
+
+	var results []GrafanaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return 0, err
+	}
+	return len(results), nil
+}
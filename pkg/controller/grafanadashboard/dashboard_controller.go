@@ -4,6 +4,7 @@ import (
 	"context"
 	defaultErrors "errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
@@ -77,9 +78,22 @@ func add(mgr manager.Manager, r reconcile.Reconciler, namespace string) error {
 		r.Reconcile(request)
 	}
 
+	// A full resync re-lists every dashboard in the namespace, which is
+	// expensive. Only do it on every tick of the ticker if the controller
+	// state actually changed since the last tick; otherwise wait until
+	// FullResyncInterval has elapsed regardless of state.
+	lastState := ref.state
+	lastFullResync := time.Now()
 	go func() {
 		for range ticker.C {
-			log.Info("running periodic dashboard resync")
+			stateChanged := !reflect.DeepEqual(lastState, ref.state)
+			dueForResync := time.Since(lastFullResync) >= config.FullResyncInterval
+			if !stateChanged && !dueForResync {
+				continue
+			}
+			log.Info("running periodic dashboard resync", "stateChanged", stateChanged, "dueForResync", dueForResync)
+			lastState = ref.state
+			lastFullResync = time.Now()
 			sendEmptyRequest()
 		}
 	}()
@@ -202,14 +216,14 @@ func (r *ReconcileGrafanaDashboard) reconcileDashboards(request reconcile.Reques
 		return false
 	}
 
-	// Returns the hash of a dashboard if it is known
-	findHash := func(item *grafanav1alpha1.GrafanaDashboard) string {
+	// Returns the known ref of a dashboard, if any
+	findRef := func(item *grafanav1alpha1.GrafanaDashboard) *grafanav1alpha1.GrafanaDashboardRef {
 		for _, d := range knownDashboards {
 			if item.Name == d.Name && item.Namespace == d.Namespace {
-				return d.Hash
+				return d
 			}
 		}
-		return ""
+		return nil
 	}
 
 	// Dashboards to delete: dashboards that are known but not found
@@ -224,24 +238,46 @@ func (r *ReconcileGrafanaDashboard) reconcileDashboards(request reconcile.Reques
 	for _, dashboard := range namespaceDashboards.Items {
 		// Is this a dashboard we care about (matches the label selectors)?
 		if !r.isMatch(&dashboard) {
-			log.V(1).Info("dashboard selector does not match", "namespace"
-				dashboard.Namespace, "name", dashboard.Name))
+			log.V(1).Info("dashboard selector does not match", "namespace", dashboard.Namespace, "name", dashboard.Name)
 			continue
 		}
 
-		// Process the dashboard. Use the known hash of an existing dashboard
-		// to determine if an update is required
-		knownHash := findHash(&dashboard)
-		pipeline := NewDashboardPipeline(r.client, &dashboard)
-		processed, err := pipeline.ProcessDashboard(knownHash)
+		// Process the dashboard. Use the known hash of an existing dashboard,
+		// plus the hash last persisted to its status, to determine if an
+		// update is required
+		knownRef := findRef(&dashboard)
+		knownHash := ""
+		if knownRef != nil {
+			knownHash = knownRef.Hash
+		}
+
+		// The in-memory/status hashes only tell us the dashboard hasn't
+		// changed on our side. If it was deleted directly in Grafana the
+		// hashes still match, so explicitly verify it is still there and
+		// force a re-push if not.
+		if knownRef != nil && knownRef.UID != "" {
+			existing, err := grafanaClient.GetDashboardByUID(knownRef.UID)
+			if err != nil {
+				log.Error(err, "failed to verify dashboard still exists in grafana", "namespace", dashboard.Namespace, "name", dashboard.Name)
+			} else if existing == nil {
+				log.Info("dashboard missing in grafana, forcing recreation", "namespace", dashboard.Namespace, "name", dashboard.Name)
+				knownHash = ""
+			}
+		}
+
+		pipeline := NewDashboardPipeline(r.client, &dashboard, r.state.DashboardNamespaceSelector)
+		processed, err := pipeline.ProcessDashboard(knownHash, dashboard.Status.LastAppliedHash)
 
 		if err != nil {
-			log.Error(err, "cannot process dashboard", "namespace", dashboard.Namespace, "name", dashboard.Name))
+			dashboardReconcileTotal.WithLabelValues("error").Inc()
+			log.Error(err, "cannot process dashboard", "namespace", dashboard.Namespace, "name", dashboard.Name)
 			r.manageError(&dashboard, err)
 			continue
 		}
 
 		if processed == nil {
+			dashboardReconcileTotal.WithLabelValues("noop").Inc()
+			log.V(1).Info("dashboard unchanged, skipping", "namespace", dashboard.Namespace, "name", dashboard.Name)
 			r.config.SetPluginsFor(&dashboard)
 			continue
 		}
@@ -254,14 +290,14 @@ func (r *ReconcileGrafanaDashboard) reconcileDashboards(request reconcile.Reques
 			}
 
 			if matchesNamespaceLabels == false {
-				log.V(1).Info("dashboard skipped because the namespace labels do not match", "name", dashboard.Name))
+				log.V(1).Info("dashboard skipped because the namespace labels do not match", "name", dashboard.Name)
 				continue
 			}
 		}
 
-		folder, err := grafanaClient.GetOrCreateNamespaceFolder(dashboard.Namespace)
+		folder, isNamespaceDefault, err := r.resolveFolder(&dashboard, grafanaClient)
 		if err != nil {
-			log.Error(err, "failed to get or create namespace folder", "namespace", dashboard.Namespace, "name", dashboard.Name))
+			log.Error(err, "failed to resolve folder for dashboard", "namespace", dashboard.Namespace, "name", dashboard.Name)
 			r.manageError(&dashboard, err)
 			continue
 		}
@@ -272,24 +308,47 @@ func (r *ReconcileGrafanaDashboard) reconcileDashboards(request reconcile.Reques
 		} else {
 			folderId = *folder.ID
 		}
+		if isNamespaceDefault {
+			dashboard.Status.OperatorFolderUID = folder.UID
+		} else {
+			dashboard.Status.OperatorFolderUID = ""
+		}
 
-		_, err = grafanaClient.CreateOrUpdateDashboard(processed, folderId)
+		dashboardApiCallsTotal.Inc()
+		resp, err := grafanaClient.CreateOrUpdateDashboard(processed, folderId)
 		if err != nil {
-			log.Error(err, "cannot submit dashboard %v/%v", dashboard.Namespace, dashboard.Name)
+			dashboardReconcileTotal.WithLabelValues("error").Inc()
+			log.Error(err, "cannot submit dashboard", "namespace", dashboard.Namespace, "name", dashboard.Name)
 			r.manageError(&dashboard, err)
 			continue
 		}
-		r.manageSuccess(&dashboard)
+		if resp.UID != nil {
+			dashboard.Status.UID = *resp.UID
+		}
+		dashboardReconcileTotal.WithLabelValues("updated").Inc()
+		r.manageSuccess(&dashboard, pipeline.Hash())
 	}
 
 	for _, dashboard := range dashboardsToDelete {
+		dashboardApiCallsTotal.Inc()
 		status, err := grafanaClient.DeleteDashboardByUID(dashboard.UID)
 		if err != nil {
-			log.Error(err, "fail deleting dashboard", "UID", dashboard.UID, "Status", *status.Status, "Message", *status.Message)
+			dashboardReconcileTotal.WithLabelValues("error").Inc()
+			log.Error(err, "fail deleting dashboard", "UID", dashboard.UID)
+			continue
 		}
-		log.Info("dashboard deleted", "status", *status.Message))
+		dashboardReconcileTotal.WithLabelValues("deleted").Inc()
+		log.Info("dashboard deleted", "status", *status.Message)
 		r.config.RemovePluginsFor(dashboard.Namespace, dashboard.Name)
 		r.config.RemoveDashboard(dashboard.Namespace, dashboard.Name)
+
+		// Garbage collect the namespace-default folder if the operator
+		// created it and it is now empty. Folders referenced via
+		// spec.folder or a GrafanaFolder CR are left alone; they are not
+		// ours to delete.
+		if dashboard.FolderName != "" {
+			r.reapEmptyFolder(dashboard.FolderName, grafanaClient)
+		}
 	}
 
 	// Mark the dashboards as synced so that the current state can be written
@@ -299,21 +358,36 @@ func (r *ReconcileGrafanaDashboard) reconcileDashboards(request reconcile.Reques
 }
 
 // Handle success case: update dashboard metadata (id, uid) and update the list
-// of plugins
-func (r *ReconcileGrafanaDashboard) manageSuccess(dashboard *grafanav1alpha1.GrafanaDashboard) {
-	r.recorder.Event(dashboard, "Normal", "Success", msg)
+// of plugins. The status is persisted to the apiserver so LastAppliedHash
+// survives a reconcile and can actually suppress a no-op push next time
+// (see ProcessDashboard).
+func (r *ReconcileGrafanaDashboard) manageSuccess(dashboard *grafanav1alpha1.GrafanaDashboard, hash string) {
+	r.recorder.Event(dashboard, "Normal", "Success", "dashboard successfully submitted")
 	log.Info("dashboard successfully submitted",
-		"Namespace" dashboard.Namespace,
-		"Name", dashboard.Name)
+		"namespace", dashboard.Namespace,
+		"name", dashboard.Name)
+	dashboard.Status.Hash = hash
+	dashboard.Status.LastAppliedHash = hash
+	if err := r.client.Status().Update(r.context, dashboard); err != nil {
+		log.Error(err, "failed to update dashboard status", "namespace", dashboard.Namespace, "name", dashboard.Name)
+	}
 	r.config.AddDashboard(dashboard)
 	r.config.SetPluginsFor(dashboard)
 }
 
-// Handle error case: update dashboard with error message and status
+// Handle error case: update dashboard with error message and status. Errors
+// raised by the content pipeline carry their own event reason (e.g.
+// FetchFailed, JsonnetEvalFailed) so operators can tell a bad dashboard
+// source apart from a Grafana API failure.
 func (r *ReconcileGrafanaDashboard) manageError(dashboard *grafanav1alpha1.GrafanaDashboard, issue error) {
-	r.recorder.Event(dashboard, "Warning", "ProcessingError", issue.Error())
+	reason := "ProcessingError"
+	var pipelineErr *PipelineError
+	if defaultErrors.As(issue, &pipelineErr) {
+		reason = pipelineErr.Reason
+	}
+	r.recorder.Event(dashboard, "Warning", reason, issue.Error())
 
-	// Ignore conclicts. Resource might just be outdated.
+	// Ignore conflicts. Resource might just be outdated.
 	if errors.IsConflict(issue) {
 		return
 	}
@@ -341,6 +415,100 @@ func (r *ReconcileGrafanaDashboard) getClient() (GrafanaClient, error) {
 	return NewGrafanaClient(url, username, password, duration), nil
 }
 
+// resolveFolder picks the Grafana folder a dashboard should be filed into,
+// in priority order: an explicit spec.folder override, a GrafanaFolder CR
+// whose namespace selector matches the dashboard's namespace, or finally the
+// implicit per-namespace default. The second return value reports whether
+// the namespace default was used, so the caller can track it for garbage
+// collection.
+func (r *ReconcileGrafanaDashboard) resolveFolder(dashboard *grafanav1alpha1.GrafanaDashboard, grafanaClient GrafanaClient) (*GrafanaFolder, bool, error) {
+	namespaceSelectorMatches := func(selector *metav1.LabelSelector) bool {
+		if selector == nil {
+			return false
+		}
+		s, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return false
+		}
+		ns := &v1.Namespace{}
+		if err := r.client.Get(r.context, client.ObjectKey{Name: dashboard.Namespace}, ns); err != nil {
+			return false
+		}
+		return s.Matches(labels.Set(ns.Labels))
+	}
+
+	if dashboard.Spec.Folder != "" {
+		// A spec.folder override is only honored if it names a GrafanaFolder
+		// CR whose NamespaceSelector admits this dashboard's namespace.
+		// Without that check any tenant could write into any other
+		// tenant's folder by UID/name, defeating the selector-based
+		// isolation GrafanaFolder is meant to provide.
+		folders := &grafanav1alpha1.GrafanaFolderList{}
+		if err := r.client.List(r.context, folders); err != nil {
+			return nil, false, err
+		}
+
+		for _, f := range folders.Items {
+			if f.Name != dashboard.Spec.Folder && f.Status.UID != dashboard.Spec.Folder {
+				continue
+			}
+			if !namespaceSelectorMatches(f.Spec.NamespaceSelector) {
+				return nil, false, fmt.Errorf("namespace %s is not permitted to use folder %s", dashboard.Namespace, dashboard.Spec.Folder)
+			}
+			folder, err := grafanaClient.GetFolderByUIDOrName(f.Name)
+			if err != nil {
+				return nil, false, err
+			}
+			if folder == nil {
+				return nil, false, fmt.Errorf("folder %s referenced by dashboard %s/%s not found", dashboard.Spec.Folder, dashboard.Namespace, dashboard.Name)
+			}
+			return folder, false, nil
+		}
+
+		return nil, false, fmt.Errorf("folder %s referenced by dashboard %s/%s has no matching GrafanaFolder granting this namespace access", dashboard.Spec.Folder, dashboard.Namespace, dashboard.Name)
+	}
+
+	folders := &grafanav1alpha1.GrafanaFolderList{}
+	if err := r.client.List(r.context, folders); err == nil {
+		for _, f := range folders.Items {
+			if !namespaceSelectorMatches(f.Spec.NamespaceSelector) {
+				continue
+			}
+			folder, err := grafanaClient.GetFolderByUIDOrName(f.Name)
+			if err != nil {
+				return nil, false, err
+			}
+			if folder != nil {
+				return folder, false, nil
+			}
+		}
+	}
+
+	folder, err := grafanaClient.GetOrCreateNamespaceFolder(dashboard.Namespace)
+	if err != nil {
+		return nil, false, err
+	}
+	return folder, true, nil
+}
+
+// reapEmptyFolder deletes an operator-created namespace-default folder once
+// it no longer contains any dashboards.
+func (r *ReconcileGrafanaDashboard) reapEmptyFolder(folderUID string, grafanaClient GrafanaClient) {
+	count, err := grafanaClient.CountDashboardsInFolder(folderUID)
+	if err != nil {
+		log.Error(err, "failed to check folder occupancy for garbage collection", "folderUID", folderUID)
+		return
+	}
+	if count > 0 {
+		return
+	}
+	if err := grafanaClient.DeleteFolder(folderUID); err != nil {
+		log.Error(err, "failed to garbage collect empty folder", "folderUID", folderUID)
+		return
+	}
+	log.Info("garbage collected empty operator-created folder", "folderUID", folderUID)
+}
+
 // Test if a given dashboard matches an array of label selectors
 func (r *ReconcileGrafanaDashboard) isMatch(item *grafanav1alpha1.GrafanaDashboard) bool {
 	if r.state.DashboardSelectors == nil {
@@ -0,0 +1,97 @@
+package grafanadashboard
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/common"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fakeGrafanaClient is an in-memory GrafanaClient used to drive
+// reconcileDashboards without a real Grafana instance. Dashboards are keyed
+// by UID; deleting an entry simulates the dashboard having been removed
+// directly in Grafana, out-of-band from the operator.
+type fakeGrafanaClient struct {
+	GrafanaClient
+	dashboards map[string]bool
+	created    int
+}
+
+func (f *fakeGrafanaClient) GetDashboardByUID(uid string) (*GrafanaResponse, error) {
+	if !f.dashboards[uid] {
+		return nil, nil
+	}
+	return &GrafanaResponse{UID: &uid}, nil
+}
+
+func (f *fakeGrafanaClient) CreateOrUpdateDashboard(dashboard []byte, folderId int64) (*GrafanaResponse, error) {
+	f.created++
+	uid := "fake-uid"
+	f.dashboards[uid] = true
+	return &GrafanaResponse{UID: &uid}, nil
+}
+
+func (f *fakeGrafanaClient) GetOrCreateNamespaceFolder(namespace string) (*GrafanaFolder, error) {
+	return &GrafanaFolder{UID: namespace}, nil
+}
+
+// TestReconcileDashboards_RecreatesDashboardDeletedInGrafana verifies the
+// drift check added to reconcileDashboards: when a dashboard the operator
+// believes is already applied is no longer found in Grafana, it is re-pushed
+// on the next tick instead of being skipped as a no-op.
+func TestReconcileDashboards_RecreatesDashboardDeletedInGrafana(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := grafanav1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	// The known and last-applied hashes both match the content, so without
+	// the drift check the dashboard would be skipped as a no-op.
+	content := `{"title": "test"}`
+	appliedHash := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+
+	dashboard := &grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dashboard", Namespace: "default"},
+		Spec:       grafanav1alpha1.GrafanaDashboardSpec{Json: content},
+		Status:     grafanav1alpha1.GrafanaDashboardStatus{UID: "fake-uid", Hash: appliedHash, LastAppliedHash: appliedHash},
+	}
+
+	cl := fake.NewFakeClientWithScheme(scheme, dashboard)
+
+	cfg := config.GetControllerConfig()
+	cfg.AddDashboard(&grafanav1alpha1.GrafanaDashboard{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dashboard", Namespace: "default"},
+		Status:     grafanav1alpha1.GrafanaDashboardStatus{UID: "fake-uid", Hash: appliedHash},
+	})
+
+	r := &ReconcileGrafanaDashboard{
+		client:   cl,
+		config:   cfg,
+		context:  context.Background(),
+		recorder: record.NewFakeRecorder(10),
+		state: common.ControllerState{
+			GrafanaReady:       true,
+			DashboardSelectors: []*metav1.LabelSelector{{}},
+		},
+	}
+
+	grafanaClient := &fakeGrafanaClient{dashboards: map[string]bool{}}
+
+	if _, err := r.reconcileDashboards(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default"}}, grafanaClient); err != nil {
+		t.Fatalf("reconcileDashboards returned error: %v", err)
+	}
+
+	if grafanaClient.created != 1 {
+		t.Fatalf("expected dashboard deleted out-of-band to be recreated, got %d CreateOrUpdateDashboard calls", grafanaClient.created)
+	}
+}
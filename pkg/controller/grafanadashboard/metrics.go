@@ -0,0 +1,20 @@
+package grafanadashboard
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var dashboardReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "grafana_dashboard_reconcile_total",
+	Help: "Number of dashboard reconciles, partitioned by result",
+}, []string{"result"})
+
+var dashboardApiCallsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "grafana_dashboard_api_calls_total",
+	Help: "Number of calls made to the Grafana dashboard API",
+})
+
+func init() {
+	metrics.Registry.MustRegister(dashboardReconcileTotal, dashboardApiCallsTotal)
+}
@@ -0,0 +1,342 @@
+package grafanadatasource
+
+import (
+	"context"
+	defaultErrors "errors"
+	"fmt"
+	"time"
+
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/common"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/config"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/grafanadashboard"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	ControllerName = "controller_grafanadatasource"
+)
+
+var log = logf.Log.WithName(ControllerName)
+
+// Add creates a new GrafanaDataSource Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager, namespace string) error {
+	return add(mgr, newReconciler(mgr), namespace)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &ReconcileGrafanaDataSource{
+		client:   mgr.GetClient(),
+		config:   config.GetControllerConfig(),
+		context:  ctx,
+		cancel:   cancel,
+		recorder: mgr.GetEventRecorderFor(ControllerName),
+		state:    common.ControllerState{},
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler, namespace string) error {
+	// Create a new controller
+	c, err := controller.New("grafanadatasource-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource GrafanaDataSource
+	err = c.Watch(&source.Kind{Type: &grafanav1alpha1.GrafanaDataSource{}}, &handler.EnqueueRequestForObject{})
+	if err == nil {
+		log.Info("Starting datasource controller")
+	}
+
+	ref := r.(*ReconcileGrafanaDataSource)
+	ticker := time.NewTicker(config.RequeueDelay)
+	sendEmptyRequest := func() {
+		request := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      "",
+			},
+		}
+		r.Reconcile(request)
+	}
+
+	go func() {
+		for range ticker.C {
+			log.Info("running periodic datasource resync")
+			sendEmptyRequest()
+		}
+	}()
+
+	go func() {
+		for stateChange := range common.ControllerEvents {
+			// Controller state updated
+			ref.state = stateChange
+		}
+	}()
+
+	return err
+}
+
+var _ reconcile.Reconciler = &ReconcileGrafanaDataSource{}
+
+// ReconcileGrafanaDataSource reconciles a GrafanaDataSource object
+type ReconcileGrafanaDataSource struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client   client.Client
+	config   *config.ControllerConfig
+	context  context.Context
+	cancel   context.CancelFunc
+	recorder record.EventRecorder
+	state    common.ControllerState
+}
+
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileGrafanaDataSource) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	// If Grafana is not running there is no need to continue
+	if r.state.GrafanaReady == false {
+		log.V(1).Info("no grafana instance available")
+		return reconcile.Result{Requeue: false}, nil
+	}
+
+	grafanaClient, err := r.getClient()
+	if err != nil {
+		return reconcile.Result{RequeueAfter: config.RequeueDelay}, nil
+	}
+
+	// Initial request?
+	if request.Name == "" {
+		return r.reconcileDatasources(request, grafanaClient)
+	}
+
+	// Check if the label selectors are available yet. If not then the grafana controller
+	// has not finished initializing and we can't continue. Reschedule for later.
+	if r.state.DataSourceSelectors == nil {
+		return reconcile.Result{RequeueAfter: config.RequeueDelay}, nil
+	}
+
+	// Fetch the GrafanaDataSource instance
+	instance := &grafanav1alpha1.GrafanaDataSource{}
+	err = r.client.Get(r.context, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// If some datasource has been deleted, then always re sync the world
+			log.Info(fmt.Sprintf("deleting datasource %v/%v", request.Namespace, request.Name))
+			return r.reconcileDatasources(request, grafanaClient)
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	// If the datasource does not match the label selectors then we ignore it
+	cr := instance.DeepCopy()
+	if !r.isMatch(cr) {
+		log.V(1).Info("selectors does not match", "namespace", cr.Namespace, "name", cr.Name)
+		return reconcile.Result{}, nil
+	}
+
+	// Otherwise always re sync all datasources in the namespace
+	return r.reconcileDatasources(request, grafanaClient)
+}
+
+// check if the labels on a namespace match a given label selector
+func (r *ReconcileGrafanaDataSource) checkNamespaceLabels(cr *grafanav1alpha1.GrafanaDataSource) (bool, error) {
+	key := client.ObjectKey{
+		Name: cr.Namespace,
+	}
+	ns := &v1.Namespace{}
+	err := r.client.Get(r.context, key, ns)
+	if err != nil {
+		return false, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(r.state.DataSourceNamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Empty() || selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+func (r *ReconcileGrafanaDataSource) reconcileDatasources(request reconcile.Request, grafanaClient grafanadashboard.GrafanaClient) (reconcile.Result, error) {
+	// Collect known and namespace datasources
+	knownDatasources := r.config.GetDatasources(request.Namespace)
+	namespaceDatasources := &grafanav1alpha1.GrafanaDataSourceList{}
+
+	opts := &client.ListOptions{
+		Namespace: request.Namespace,
+	}
+
+	err := r.client.List(r.context, namespaceDatasources, opts)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Prepare lists
+	var datasourcesToDelete []*grafanav1alpha1.GrafanaDataSourceRef
+
+	// Check if a given datasource (by name) is present in the list of
+	// datasources in the namespace
+	inNamespace := func(item *grafanav1alpha1.GrafanaDataSourceRef) bool {
+		for _, d := range namespaceDatasources.Items {
+			if d.Name == item.Name && d.Namespace == item.Namespace {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Returns the hash of a datasource if it is known
+	findHash := func(item *grafanav1alpha1.GrafanaDataSource) string {
+		for _, d := range knownDatasources {
+			if item.Name == d.Name && item.Namespace == d.Namespace {
+				return d.Hash
+			}
+		}
+		return ""
+	}
+
+	// Datasources to delete: datasources that are known but not found
+	// any longer in the namespace
+	for _, datasource := range knownDatasources {
+		if !inNamespace(datasource) {
+			datasourcesToDelete = append(datasourcesToDelete, datasource)
+		}
+	}
+
+	// Process new/updated datasources
+	for _, datasource := range namespaceDatasources.Items {
+		// Is this a datasource we care about (matches the label selectors)?
+		if !r.isMatch(&datasource) {
+			log.V(1).Info("datasource selector does not match", "namespace", datasource.Namespace, "name", datasource.Name)
+			continue
+		}
+
+		// Check labels only when DataSourceNamespaceSelector isn't empty
+		if r.state.DataSourceNamespaceSelector != nil {
+			matchesNamespaceLabels, err := r.checkNamespaceLabels(&datasource)
+			if err != nil {
+				r.manageError(&datasource, err)
+				continue
+			}
+
+			if matchesNamespaceLabels == false {
+				log.V(1).Info("datasource skipped because the namespace labels do not match", "name", datasource.Name)
+				continue
+			}
+		}
+
+		// Process the datasource. Use the known hash of an existing datasource
+		// to determine if an update is required
+		knownHash := findHash(&datasource)
+		pipeline := NewDatasourcePipeline(r.client, &datasource)
+		processed, err := pipeline.ProcessDatasource(knownHash)
+		if err != nil {
+			log.Error(err, "cannot process datasource", "namespace", datasource.Namespace, "name", datasource.Name)
+			r.manageError(&datasource, err)
+			continue
+		}
+
+		if processed == nil {
+			r.config.AddConfigItem(config.ConfigGrafanaDataSourcesSynced, true)
+			continue
+		}
+
+		resp, err := grafanaClient.CreateOrUpdateDatasource(processed)
+		if err != nil {
+			log.Error(err, "cannot submit datasource", "namespace", datasource.Namespace, "name", datasource.Name)
+			r.manageError(&datasource, err)
+			continue
+		}
+		if resp.UID != nil {
+			datasource.Status.UID = *resp.UID
+		}
+		r.manageSuccess(&datasource, pipeline.Hash())
+	}
+
+	for _, datasource := range datasourcesToDelete {
+		status, err := grafanaClient.DeleteDatasourceByUID(datasource.UID)
+		if err != nil {
+			log.Error(err, "error deleting datasource", "UID", datasource.UID)
+			continue
+		}
+		log.Info("datasource deleted", "status", *status.Message)
+		r.config.RemoveDatasource(datasource.Namespace, datasource.Name)
+	}
+
+	// Mark the datasources as synced so that the current state can be written
+	// to the Grafana CR by the grafana controller
+	r.config.AddConfigItem(config.ConfigGrafanaDataSourcesSynced, true)
+	return reconcile.Result{Requeue: false}, nil
+}
+
+// Handle success case: update datasource metadata (hash) and the known list
+func (r *ReconcileGrafanaDataSource) manageSuccess(cr *grafanav1alpha1.GrafanaDataSource, hash string) {
+	r.recorder.Event(cr, "Normal", "Success", "datasource successfully submitted")
+	log.Info("datasource successfully submitted", "namespace", cr.Namespace, "name", cr.Name)
+	cr.Status.Hash = hash
+	r.config.AddDatasource(cr)
+}
+
+// Handle error case: update datasource with error message and status
+func (r *ReconcileGrafanaDataSource) manageError(cr *grafanav1alpha1.GrafanaDataSource, issue error) {
+	r.recorder.Event(cr, "Warning", "ProcessingError", issue.Error())
+
+	// Ignore conflicts. Resource might just be outdated.
+	if errors.IsConflict(issue) {
+		return
+	}
+	log.Error(issue, "error updating datasource")
+}
+
+// Get an authenticated grafana API client
+func (r *ReconcileGrafanaDataSource) getClient() (grafanadashboard.GrafanaClient, error) {
+	url := r.state.AdminUrl
+	if url == "" {
+		return nil, defaultErrors.New("cannot get grafana admin url")
+	}
+
+	username := r.state.AdminUsername
+	if username == "" {
+		return nil, defaultErrors.New("invalid credentials (username)")
+	}
+
+	password := r.state.AdminPassword
+	if password == "" {
+		return nil, defaultErrors.New("invalid credentials (password)")
+	}
+
+	duration := time.Duration(r.state.ClientTimeout)
+	return grafanadashboard.NewGrafanaClient(url, username, password, duration), nil
+}
+
+// Test if a given datasource matches an array of label selectors
+func (r *ReconcileGrafanaDataSource) isMatch(item *grafanav1alpha1.GrafanaDataSource) bool {
+	if r.state.DataSourceSelectors == nil {
+		return false
+	}
+
+	match, err := item.MatchesSelectors(r.state.DataSourceSelectors)
+	if err != nil {
+		log.Error(err, "error matching selectors against", "namespace", item.Namespace, "name", item.Name)
+		return false
+	}
+	return match
+}
@@ -0,0 +1,145 @@
+package grafanadatasource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	defaultErrors "errors"
+	"fmt"
+
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DatasourcePipeline turns a GrafanaDataSource CR into the JSON payload
+// expected by Grafana's /api/datasources endpoint, resolving any credentials
+// that are sourced from a Secret or ConfigMap along the way.
+type DatasourcePipeline struct {
+	Client     client.Client
+	Context    context.Context
+	DataSource *grafanav1alpha1.GrafanaDataSource
+	JSON       string
+}
+
+// NewDatasourcePipeline returns a new DatasourcePipeline for a datasource.
+func NewDatasourcePipeline(client client.Client, cr *grafanav1alpha1.GrafanaDataSource) *DatasourcePipeline {
+	return &DatasourcePipeline{
+		Client:     client,
+		Context:    context.Background(),
+		DataSource: cr,
+	}
+}
+
+// ProcessDatasource resolves the datasource spec into a JSON payload. The
+// CR's status (a single Hash/UID pair) tracks exactly one Grafana datasource,
+// so exactly one entry in spec.datasources is required; if the resulting
+// hash matches knownHash, nil is returned so the caller can skip the API
+// call entirely.
+func (p *DatasourcePipeline) ProcessDatasource(knownHash string) ([]byte, error) {
+	if len(p.DataSource.Spec.Datasources) == 0 {
+		return nil, defaultErrors.New("no datasources defined")
+	}
+	if len(p.DataSource.Spec.Datasources) > 1 {
+		return nil, fmt.Errorf("only a single entry in spec.datasources is supported per GrafanaDataSource, got %v", len(p.DataSource.Spec.Datasources))
+	}
+
+	fields := p.DataSource.Spec.Datasources[0]
+
+	resolved, err := p.resolveSecureJsonDataFrom(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"name":              fields.Name,
+		"type":              fields.Type,
+		"access":            fields.Access,
+		"orgId":             fields.OrgId,
+		"url":               fields.Url,
+		"password":          fields.Password,
+		"user":              fields.User,
+		"database":          fields.Database,
+		"basicAuth":         fields.BasicAuth,
+		"basicAuthUser":     fields.BasicAuthUser,
+		"basicAuthPassword": fields.BasicAuthPassword,
+		"withCredentials":   fields.WithCredentials,
+		"isDefault":         fields.IsDefault,
+		"jsonData":          fields.JsonData,
+		"secureJsonData":    resolved,
+		"version":           fields.Version,
+		"editable":          fields.Editable,
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	p.JSON = string(raw)
+	hash := p.Hash()
+	if hash == knownHash {
+		return nil, nil
+	}
+	return raw, nil
+}
+
+// Hash returns the sha256 of the processed JSON, used to detect no-op
+// updates the same way GrafanaDashboard does.
+func (p *DatasourcePipeline) Hash() string {
+	sum := sha256.Sum256([]byte(p.JSON))
+	return fmt.Sprintf("%x", sum)
+}
+
+// resolveSecureJsonDataFrom overlays values sourced from a Secret or
+// ConfigMap onto the inline secureJsonData block.
+func (p *DatasourcePipeline) resolveSecureJsonDataFrom(fields grafanav1alpha1.GrafanaDataSourceFields) (grafanav1alpha1.GrafanaDataSourceSecureJsonData, error) {
+	secure := fields.SecureJsonData
+
+	for key, from := range fields.SecureJsonDataFrom {
+		value, err := p.resolveValueFrom(from)
+		if err != nil {
+			return secure, err
+		}
+		switch key {
+		case "password":
+			secure.Password = value
+		case "basicAuthPassword":
+			secure.BasicAuthPassword = value
+		case "tlsCACert":
+			secure.TlsCACert = value
+		case "tlsClientCert":
+			secure.TlsClientCert = value
+		case "tlsClientKey":
+			secure.TlsClientKey = value
+		case "accessKey":
+			secure.AccessKey = value
+		case "secretKey":
+			secure.SecretKey = value
+		}
+	}
+
+	return secure, nil
+}
+
+func (p *DatasourcePipeline) resolveValueFrom(from grafanav1alpha1.GrafanaDataSourceValueFrom) (string, error) {
+	if from.SecretKeyRef != nil {
+		secret := &v1.Secret{}
+		key := client.ObjectKey{Namespace: p.DataSource.Namespace, Name: from.SecretKeyRef.Name}
+		if err := p.Client.Get(p.Context, key, secret); err != nil {
+			return "", err
+		}
+		return string(secret.Data[from.SecretKeyRef.Key]), nil
+	}
+
+	if from.ConfigMapKeyRef != nil {
+		cm := &v1.ConfigMap{}
+		key := client.ObjectKey{Namespace: p.DataSource.Namespace, Name: from.ConfigMapKeyRef.Name}
+		if err := p.Client.Get(p.Context, key, cm); err != nil {
+			return "", err
+		}
+		return cm.Data[from.ConfigMapKeyRef.Key], nil
+	}
+
+	return "", defaultErrors.New("secureJsonDataFrom entry has neither secretKeyRef nor configMapKeyRef set")
+}
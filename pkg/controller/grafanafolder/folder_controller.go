@@ -0,0 +1,154 @@
+package grafanafolder
+
+import (
+	"context"
+	defaultErrors "errors"
+	"time"
+
+	grafanav1alpha1 "github.com/integr8ly/grafana-operator/v3/pkg/apis/integreatly/v1alpha1"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/common"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/config"
+	"github.com/integr8ly/grafana-operator/v3/pkg/controller/grafanadashboard"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const ControllerName = "controller_grafanafolder"
+
+var log = logf.Log.WithName(ControllerName)
+
+// Add creates a new GrafanaFolder Controller and adds it to the Manager.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileGrafanaFolder{
+		client:   mgr.GetClient(),
+		config:   config.GetControllerConfig(),
+		context:  context.Background(),
+		recorder: mgr.GetEventRecorderFor(ControllerName),
+		state:    common.ControllerState{},
+	}
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("grafanafolder-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: &grafanav1alpha1.GrafanaFolder{}}, &handler.EnqueueRequestForObject{})
+	if err == nil {
+		log.Info("Starting folder controller")
+	}
+
+	ref := r.(*ReconcileGrafanaFolder)
+	go func() {
+		for stateChange := range common.ControllerEvents {
+			ref.state = stateChange
+		}
+	}()
+
+	return err
+}
+
+var _ reconcile.Reconciler = &ReconcileGrafanaFolder{}
+
+// ReconcileGrafanaFolder reconciles a GrafanaFolder object
+type ReconcileGrafanaFolder struct {
+	client   client.Client
+	config   *config.ControllerConfig
+	context  context.Context
+	recorder record.EventRecorder
+	state    common.ControllerState
+}
+
+func (r *ReconcileGrafanaFolder) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	if r.state.GrafanaReady == false {
+		log.V(1).Info("no grafana instance available")
+		return reconcile.Result{Requeue: false}, nil
+	}
+
+	grafanaClient, err := r.getClient()
+	if err != nil {
+		return reconcile.Result{RequeueAfter: config.RequeueDelay}, nil
+	}
+
+	instance := &grafanav1alpha1.GrafanaFolder{}
+	err = r.client.Get(r.context, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The folder CR is gone; the folder itself is left in place
+			// since dashboards may still reference it directly.
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	cr := instance.DeepCopy()
+	title := cr.Spec.Title
+	if title == "" {
+		title = cr.Name
+	}
+
+	folder, err := grafanaClient.CreateOrUpdateFolder(cr.Name, title)
+	if err != nil {
+		r.manageError(cr, err)
+		return reconcile.Result{RequeueAfter: config.RequeueDelay}, nil
+	}
+
+	// Always reapply permissions, even when spec.permissions is empty, so
+	// removing every entry correctly clears stale ACLs from Grafana.
+	if err := grafanaClient.SetFolderPermissions(folder.UID, cr.Spec.Permissions); err != nil {
+		r.manageError(cr, err)
+		return reconcile.Result{RequeueAfter: config.RequeueDelay}, nil
+	}
+
+	cr.Status.UID = folder.UID
+	if folder.ID != nil {
+		cr.Status.ID = *folder.ID
+	}
+	if err := r.client.Status().Update(r.context, cr); err != nil {
+		log.Error(err, "failed to update folder status", "name", cr.Name)
+	}
+
+	r.recorder.Event(cr, "Normal", "Success", "folder successfully reconciled")
+	log.Info("folder successfully reconciled", "name", cr.Name, "uid", folder.UID)
+	return reconcile.Result{Requeue: false}, nil
+}
+
+func (r *ReconcileGrafanaFolder) manageError(cr *grafanav1alpha1.GrafanaFolder, issue error) {
+	r.recorder.Event(cr, "Warning", "ProcessingError", issue.Error())
+	if errors.IsConflict(issue) {
+		return
+	}
+	log.Error(issue, "error reconciling folder")
+}
+
+func (r *ReconcileGrafanaFolder) getClient() (grafanadashboard.GrafanaClient, error) {
+	url := r.state.AdminUrl
+	if url == "" {
+		return nil, defaultErrors.New("cannot get grafana admin url")
+	}
+
+	username := r.state.AdminUsername
+	if username == "" {
+		return nil, defaultErrors.New("invalid credentials (username)")
+	}
+
+	password := r.state.AdminPassword
+	if password == "" {
+		return nil, defaultErrors.New("invalid credentials (password)")
+	}
+
+	duration := time.Duration(r.state.ClientTimeout)
+	return grafanadashboard.NewGrafanaClient(url, username, password, duration), nil
+}